@@ -0,0 +1,153 @@
+package main
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"upload-service/s3fake"
+
+	pb "upload-service/proto"
+)
+
+func TestUploadHandler_UploadMedia(t *testing.T) {
+	uploadservice := NewUploadServiceWithStorage(s3fake.NewStorage())
+
+	type fields struct {
+		UploadService UploadService
+	}
+	type args struct {
+		ctx     context.Context
+		request *pb.UploadMediaRequest
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    *pb.UploadMediaResponse
+		wantErr bool
+	}{
+		{
+			name:   "UploadMedia - text file",
+			fields: fields{UploadService: *uploadservice},
+			args: args{
+				ctx: context.Background(),
+				request: &pb.UploadMediaRequest{
+					Key:    "testfile.txt",
+					Bucket: "testbucket",
+					File:   []byte("Hello, World!"),
+				},
+			},
+			wantErr: false,
+			want: &pb.UploadMediaResponse{
+				Output: "fake://testbucket/testfile.txt",
+			},
+		},
+		{
+			name:   "UploadMedia - text file - without key",
+			fields: fields{UploadService: *uploadservice},
+			args: args{
+				ctx: context.Background(),
+				request: &pb.UploadMediaRequest{
+					Key:    "",
+					Bucket: "testbucket",
+					File:   []byte("Hello, World!"),
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name:   "UploadMedia - text file - without bucket",
+			fields: fields{UploadService: *uploadservice},
+			args: args{
+				ctx: context.Background(),
+				request: &pb.UploadMediaRequest{
+					Key:    "testfile.txt",
+					Bucket: "",
+					File:   []byte("Hello, World!"),
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name:   "UploadMedia - text file - with nil file",
+			fields: fields{UploadService: *uploadservice},
+			args: args{
+				ctx: context.Background(),
+				request: &pb.UploadMediaRequest{
+					Key:    "testfile.txt",
+					Bucket: "testbucket",
+					File:   nil,
+				},
+			},
+			wantErr: false,
+			want: &pb.UploadMediaResponse{
+				Output: "fake://testbucket/testfile.txt",
+			},
+		},
+		{
+			name:   "UploadMedia - checksum mismatch is reported as an error",
+			fields: fields{UploadService: *uploadservice},
+			args: args{
+				ctx: context.Background(),
+				request: &pb.UploadMediaRequest{
+					Key:      "testfile2.txt",
+					Bucket:   "testbucket",
+					File:     []byte("Hello, World!"),
+					Checksum: "not-the-real-md5",
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := UploadHandler{
+				UploadService: tt.fields.UploadService,
+			}
+			got, err := h.UploadMedia(tt.args.ctx, tt.args.request)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("UploadHandler.UploadMedia() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != nil && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("UploadHandler.UploadMedia() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUploadHandler_GeneratePresignedUploadURL_UnsupportedBackend(t *testing.T) {
+	h := UploadHandler{UploadService: *NewUploadServiceWithStorage(s3fake.NewStorage())}
+
+	_, err := h.GeneratePresignedUploadURL(context.Background(), &pb.PresignedUploadURLRequest{
+		Key:    "testfile.txt",
+		Bucket: "testbucket",
+	})
+	if err == nil {
+		t.Error("GeneratePresignedUploadURL() error = nil, want error since s3fake.Storage does not support presigning")
+	}
+}
+
+func TestUploadHandler_GeneratePresignedUploadURL_RequiresKeyAndBucket(t *testing.T) {
+	h := UploadHandler{UploadService: *NewUploadServiceWithStorage(s3fake.NewStorage())}
+
+	if _, err := h.GeneratePresignedUploadURL(context.Background(), &pb.PresignedUploadURLRequest{Bucket: "testbucket"}); err == nil {
+		t.Error("GeneratePresignedUploadURL() error = nil, want error for empty key")
+	}
+	if _, err := h.GeneratePresignedUploadURL(context.Background(), &pb.PresignedUploadURLRequest{Key: "testfile.txt"}); err == nil {
+		t.Error("GeneratePresignedUploadURL() error = nil, want error for empty bucket")
+	}
+}
+
+func TestUploadHandler_GeneratePresignedDownloadURL_UnsupportedBackend(t *testing.T) {
+	h := UploadHandler{UploadService: *NewUploadServiceWithStorage(s3fake.NewStorage())}
+
+	_, err := h.GeneratePresignedDownloadURL(context.Background(), &pb.PresignedDownloadURLRequest{
+		Key:    "testfile.txt",
+		Bucket: "testbucket",
+	})
+	if err == nil {
+		t.Error("GeneratePresignedDownloadURL() error = nil, want error since s3fake.Storage does not support presigning")
+	}
+}
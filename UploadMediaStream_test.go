@@ -0,0 +1,140 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"io"
+	"testing"
+
+	"upload-service/s3fake"
+
+	"google.golang.org/grpc/metadata"
+
+	pb "upload-service/proto"
+)
+
+// fakeUploadMediaStreamServer is an in-process stand-in for
+// pb.UploadService_UploadMediaStreamServer, feeding a fixed sequence of
+// chunks to the handler under test.
+type fakeUploadMediaStreamServer struct {
+	ctx    context.Context
+	chunks []*pb.UploadMediaChunk
+	idx    int
+	resp   *pb.UploadMediaResponse
+}
+
+func (f *fakeUploadMediaStreamServer) Recv() (*pb.UploadMediaChunk, error) {
+	if f.idx >= len(f.chunks) {
+		return nil, io.EOF
+	}
+	c := f.chunks[f.idx]
+	f.idx++
+	return c, nil
+}
+
+func (f *fakeUploadMediaStreamServer) SendAndClose(resp *pb.UploadMediaResponse) error {
+	f.resp = resp
+	return nil
+}
+
+func (f *fakeUploadMediaStreamServer) Context() context.Context    { return f.ctx }
+func (f *fakeUploadMediaStreamServer) SetHeader(metadata.MD) error  { return nil }
+func (f *fakeUploadMediaStreamServer) SendHeader(metadata.MD) error { return nil }
+func (f *fakeUploadMediaStreamServer) SetTrailer(metadata.MD)       {}
+func (f *fakeUploadMediaStreamServer) SendMsg(m interface{}) error  { return nil }
+func (f *fakeUploadMediaStreamServer) RecvMsg(m interface{}) error  { return nil }
+
+func chunkPayload(key, bucket string, payload []byte, chunkSize int) []*pb.UploadMediaChunk {
+	var chunks []*pb.UploadMediaChunk
+	for i := 0; i < len(payload); i += chunkSize {
+		end := i + chunkSize
+		if end > len(payload) {
+			end = len(payload)
+		}
+		chunk := &pb.UploadMediaChunk{Chunk: payload[i:end]}
+		if i == 0 {
+			chunk.Key = key
+			chunk.Bucket = bucket
+		}
+		chunks = append(chunks, chunk)
+	}
+	return chunks
+}
+
+func TestUploadHandler_UploadMediaStream_LargePayload(t *testing.T) {
+	const totalSize = 6 * 1024 * 1024 // exceeds the 5MiB default part size
+	payload := make([]byte, totalSize)
+	if _, err := rand.Read(payload); err != nil {
+		t.Fatalf("failed to generate payload: %v", err)
+	}
+
+	fakeStorage := s3fake.NewStorage()
+	h := &UploadHandler{UploadService: *NewUploadServiceWithStorage(fakeStorage)}
+
+	stream := &fakeUploadMediaStreamServer{
+		ctx:    context.Background(),
+		chunks: chunkPayload("bigfile.bin", "testbucket", payload, 64*1024),
+	}
+
+	if err := h.UploadMediaStream(stream); err != nil {
+		t.Fatalf("UploadMediaStream() error = %v", err)
+	}
+
+	want := "fake://testbucket/bigfile.bin"
+	if stream.resp == nil || stream.resp.Output != want {
+		t.Errorf("UploadMediaStream() output = %v, want %v", stream.resp, want)
+	}
+
+	got, ok := fakeStorage.Get("testbucket", "bigfile.bin")
+	if !ok {
+		t.Fatal("UploadMediaStream() did not store the uploaded object")
+	}
+	if !bytes.Equal(got, payload) {
+		t.Error("UploadMediaStream() stored payload does not match the streamed chunks")
+	}
+}
+
+// disconnectingStream simulates a client that drops the connection after
+// its first chunk.
+type disconnectingStream struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+	first  *pb.UploadMediaChunk
+	sent   bool
+}
+
+func (d *disconnectingStream) Recv() (*pb.UploadMediaChunk, error) {
+	if !d.sent {
+		d.sent = true
+		return d.first, nil
+	}
+	d.cancel()
+	return nil, context.Canceled
+}
+
+func (d *disconnectingStream) SendAndClose(*pb.UploadMediaResponse) error { return nil }
+func (d *disconnectingStream) Context() context.Context                  { return d.ctx }
+func (d *disconnectingStream) SetHeader(metadata.MD) error                { return nil }
+func (d *disconnectingStream) SendHeader(metadata.MD) error               { return nil }
+func (d *disconnectingStream) SetTrailer(metadata.MD)                    {}
+func (d *disconnectingStream) SendMsg(m interface{}) error               { return nil }
+func (d *disconnectingStream) RecvMsg(m interface{}) error               { return nil }
+
+func TestUploadHandler_UploadMediaStream_ClientDisconnect(t *testing.T) {
+	h := &UploadHandler{UploadService: *NewUploadServiceWithStorage(s3fake.NewStorage())}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stream := &disconnectingStream{
+		ctx:    ctx,
+		cancel: cancel,
+		first:  &pb.UploadMediaChunk{Key: "partial.bin", Bucket: "testbucket", Chunk: []byte("partial data")},
+	}
+
+	if err := h.UploadMediaStream(stream); err == nil {
+		t.Error("UploadMediaStream() error = nil, want error on client disconnect")
+	}
+	if ctx.Err() == nil {
+		t.Error("UploadMediaStream() did not cancel the upload context on client disconnect")
+	}
+}
@@ -0,0 +1,346 @@
+package main
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"io"
+	"testing"
+
+	"upload-service/s3fake"
+	"upload-service/storage"
+
+	"github.com/aws/aws-sdk-go/aws"
+)
+
+func TestUploadService_UploadFile(t *testing.T) {
+
+	type args struct {
+		file     io.Reader
+		key      *string
+		bucket   *string
+		metadata map[string]*string
+		opts     UploadOptions
+	}
+
+	tests := []struct {
+		name    string
+		args    args
+		want    *string
+		wantErr bool
+	}{
+		{
+			name: "upload text file",
+			args: args{
+				key:      aws.String("testfile.txt"),
+				bucket:   aws.String("testbucket"),
+				file:     bytes.NewReader([]byte("Hello, World!")),
+				metadata: nil,
+			},
+			wantErr: false,
+			want:    aws.String("fake://testbucket/testfile.txt"),
+		},
+		{
+			name: "upload text file in a folder",
+			args: args{
+				key:      aws.String("testfolder/testfile.txt"),
+				bucket:   aws.String("testbucket"),
+				file:     bytes.NewReader([]byte("Hello, World!")),
+				metadata: nil,
+			},
+			wantErr: false,
+			want:    aws.String("fake://testbucket/testfolder/testfile.txt"),
+		},
+		{
+			name: "upload text file with empty key",
+			args: args{
+				key:      aws.String(""),
+				bucket:   aws.String("testbucket"),
+				file:     bytes.NewReader([]byte("Hello, World!")),
+				metadata: nil,
+			},
+			wantErr: true,
+		},
+		{
+			name: "upload text file with empty bucket",
+			args: args{
+				key:      aws.String("testfile.txt"),
+				bucket:   aws.String(""),
+				file:     bytes.NewReader([]byte("Hello, World!")),
+				metadata: nil,
+			},
+			wantErr: true,
+		},
+		{
+			name: "upload text file with nil key",
+			args: args{
+				key:      nil,
+				bucket:   aws.String("testbucket"),
+				file:     bytes.NewReader([]byte("Hello, World!")),
+				metadata: nil,
+			},
+			wantErr: true,
+		},
+		{
+			name: "upload text file with nil bucket",
+			args: args{
+				key:      aws.String("testfile.txt"),
+				bucket:   nil,
+				file:     bytes.NewReader([]byte("Hello, World!")),
+				metadata: nil,
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewUploadServiceWithStorage(s3fake.NewStorage())
+
+			got, err := s.UploadFile(tt.args.file, tt.args.metadata, tt.args.key, tt.args.bucket, tt.args.opts)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("UploadService.UploadFile() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if got != nil && *got != *tt.want {
+				t.Errorf("UploadService.UploadFile() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUploadService_UploadFile_ContentTypeDetection(t *testing.T) {
+	fakeStorage := s3fake.NewStorage()
+	s := NewUploadServiceWithStorage(fakeStorage)
+
+	png := []byte{0x89, 'P', 'N', 'G', 0x0d, 0x0a, 0x1a, 0x0a}
+	if _, err := s.UploadFile(bytes.NewReader(png), nil, aws.String("image.png"), aws.String("testbucket"), UploadOptions{}); err != nil {
+		t.Fatalf("UploadFile() error = %v", err)
+	}
+
+	obj, ok := fakeStorage.GetObject("testbucket", "image.png")
+	if !ok {
+		t.Fatal("UploadFile() did not store the uploaded object")
+	}
+	if obj.ContentType != "image/png" {
+		t.Errorf("UploadFile() detected content type = %q, want %q", obj.ContentType, "image/png")
+	}
+}
+
+func TestUploadService_UploadFile_ExplicitContentType(t *testing.T) {
+	fakeStorage := s3fake.NewStorage()
+	s := NewUploadServiceWithStorage(fakeStorage)
+
+	if _, err := s.UploadFile(bytes.NewReader([]byte("Hello, World!")), nil, aws.String("testfile.txt"), aws.String("testbucket"), UploadOptions{ContentType: "application/custom"}); err != nil {
+		t.Fatalf("UploadFile() error = %v", err)
+	}
+
+	obj, ok := fakeStorage.GetObject("testbucket", "testfile.txt")
+	if !ok {
+		t.Fatal("UploadFile() did not store the uploaded object")
+	}
+	if obj.ContentType != "application/custom" {
+		t.Errorf("UploadFile() content type = %q, want %q", obj.ContentType, "application/custom")
+	}
+}
+
+func TestUploadService_UploadFile_ForceGzip(t *testing.T) {
+	fakeStorage := s3fake.NewStorage()
+	s := NewUploadServiceWithStorage(fakeStorage)
+
+	content := []byte("Hello, World! Hello, World! Hello, World!")
+	if _, err := s.UploadFile(bytes.NewReader(content), nil, aws.String("testfile.txt"), aws.String("testbucket"), UploadOptions{ForceGzip: true}); err != nil {
+		t.Fatalf("UploadFile() error = %v", err)
+	}
+
+	obj, ok := fakeStorage.GetObject("testbucket", "testfile.txt")
+	if !ok {
+		t.Fatal("UploadFile() did not store the uploaded object")
+	}
+	if obj.ContentEncoding != "gzip" {
+		t.Errorf("UploadFile() content encoding = %q, want %q", obj.ContentEncoding, "gzip")
+	}
+
+	gr, err := gzip.NewReader(bytes.NewReader(obj.Body))
+	if err != nil {
+		t.Fatalf("stored body is not valid gzip: %v", err)
+	}
+	defer gr.Close()
+	got, err := io.ReadAll(gr)
+	if err != nil {
+		t.Fatalf("failed to decompress stored body: %v", err)
+	}
+	if !bytes.Equal(got, content) {
+		t.Errorf("UploadFile() decompressed body = %q, want %q", got, content)
+	}
+}
+
+func TestUploadService_UploadFile_ForceGzip_SkipsUnchangedUpload(t *testing.T) {
+	fakeStorage := s3fake.NewStorage()
+	s := NewUploadServiceWithStorage(fakeStorage)
+
+	content := []byte("Hello, World! Hello, World! Hello, World!")
+
+	first, err := s.UploadFile(bytes.NewReader(content), nil, aws.String("testfile.txt"), aws.String("testbucket"), UploadOptions{ForceGzip: true})
+	if err != nil {
+		t.Fatalf("UploadFile() error = %v", err)
+	}
+
+	obj, ok := fakeStorage.GetObject("testbucket", "testfile.txt")
+	if !ok {
+		t.Fatal("UploadFile() did not store the uploaded object")
+	}
+	storedBody := obj.Body
+
+	second, err := s.UploadFile(bytes.NewReader(content), nil, aws.String("testfile.txt"), aws.String("testbucket"), UploadOptions{ForceGzip: true})
+	if err != nil {
+		t.Fatalf("UploadFile() error = %v", err)
+	}
+	if *second != *first {
+		t.Errorf("UploadFile() second call location = %q, want %q (existing location)", *second, *first)
+	}
+
+	obj, ok = fakeStorage.GetObject("testbucket", "testfile.txt")
+	if !ok {
+		t.Fatal("object disappeared from fake storage")
+	}
+	if !bytes.Equal(obj.Body, storedBody) {
+		t.Error("UploadFile() re-uploaded an unchanged gzip-compressed object instead of skipping")
+	}
+}
+
+func TestUploadService_UploadFile_SkipsUnchangedUpload(t *testing.T) {
+	fakeStorage := s3fake.NewStorage()
+	s := NewUploadServiceWithStorage(fakeStorage)
+
+	content := []byte("Hello, World!")
+	seededLocation, _, err := fakeStorage.Upload(context.Background(), "testfile.txt", "testbucket", bytes.NewReader(content), map[string]*string{
+		storage.MetadataContentType: aws.String("application/x-seeded"),
+	})
+	if err != nil {
+		t.Fatalf("seed Upload() error = %v", err)
+	}
+
+	got, err := s.UploadFile(bytes.NewReader(content), nil, aws.String("testfile.txt"), aws.String("testbucket"), UploadOptions{})
+	if err != nil {
+		t.Fatalf("UploadFile() error = %v", err)
+	}
+	if *got != seededLocation {
+		t.Errorf("UploadFile() location = %q, want %q (existing location)", *got, seededLocation)
+	}
+
+	obj, ok := fakeStorage.GetObject("testbucket", "testfile.txt")
+	if !ok {
+		t.Fatal("object disappeared from fake storage")
+	}
+	if obj.ContentType != "application/x-seeded" {
+		t.Errorf("UploadFile() re-uploaded an unchanged object; content type = %q, want unchanged %q", obj.ContentType, "application/x-seeded")
+	}
+}
+
+// multipartETagStorage wraps s3fake.Storage and reports Head ETags in
+// S3's multipart format ("<md5>-<partcount>") instead of a plain MD5, to
+// exercise UploadService against objects that went through a multipart
+// upload rather than a single PutObject.
+type multipartETagStorage struct {
+	*s3fake.Storage
+}
+
+func (s *multipartETagStorage) Head(ctx context.Context, key, bucket string) (string, string, bool, error) {
+	etag, location, exists, err := s.Storage.Head(ctx, key, bucket)
+	if exists {
+		etag += "-2"
+	}
+	return etag, location, exists, err
+}
+
+func TestUploadService_UploadFile_MultipartETagNeverSkips(t *testing.T) {
+	fakeStorage := &multipartETagStorage{Storage: s3fake.NewStorage()}
+	s := NewUploadServiceWithStorage(fakeStorage)
+
+	content := []byte("Hello, World!")
+	if _, _, err := fakeStorage.Storage.Upload(context.Background(), "testfile.txt", "testbucket", bytes.NewReader(content), map[string]*string{
+		storage.MetadataContentType: aws.String("application/x-seeded"),
+	}); err != nil {
+		t.Fatalf("seed Upload() error = %v", err)
+	}
+
+	// A multipart ETag can never equal the plain MD5 this package
+	// computes, so the idempotency check can't fire here and the
+	// upload always re-runs - see storage.Storage's "etag is
+	// best-effort" doc comment.
+	if _, err := s.UploadFile(bytes.NewReader(content), nil, aws.String("testfile.txt"), aws.String("testbucket"), UploadOptions{}); err != nil {
+		t.Fatalf("UploadFile() error = %v", err)
+	}
+
+	obj, ok := fakeStorage.GetObject("testbucket", "testfile.txt")
+	if !ok {
+		t.Fatal("object disappeared from fake storage")
+	}
+	if obj.ContentType == "application/x-seeded" {
+		t.Error("UploadFile() skipped the upload despite a multipart-style ETag that can never match the client's plain MD5")
+	}
+}
+
+func TestUploadService_UploadFile_Overwrite(t *testing.T) {
+	fakeStorage := s3fake.NewStorage()
+	s := NewUploadServiceWithStorage(fakeStorage)
+
+	content := []byte("Hello, World!")
+	if _, _, err := fakeStorage.Upload(context.Background(), "testfile.txt", "testbucket", bytes.NewReader(content), map[string]*string{
+		storage.MetadataContentType: aws.String("application/x-seeded"),
+	}); err != nil {
+		t.Fatalf("seed Upload() error = %v", err)
+	}
+
+	if _, err := s.UploadFile(bytes.NewReader(content), nil, aws.String("testfile.txt"), aws.String("testbucket"), UploadOptions{Overwrite: true}); err != nil {
+		t.Fatalf("UploadFile() error = %v", err)
+	}
+
+	obj, ok := fakeStorage.GetObject("testbucket", "testfile.txt")
+	if !ok {
+		t.Fatal("object disappeared from fake storage")
+	}
+	if obj.ContentType == "application/x-seeded" {
+		t.Error("UploadFile() with Overwrite = true skipped the upload, want re-upload")
+	}
+}
+
+// streamOptsCapturingStorage wraps s3fake.Storage and implements
+// storage.StreamUploader purely to capture the StreamOptions it was
+// called with, so tests can assert UploadFileStream threads them through.
+type streamOptsCapturingStorage struct {
+	*s3fake.Storage
+	gotOpts storage.StreamOptions
+}
+
+func (s *streamOptsCapturingStorage) UploadStream(ctx context.Context, key, bucket string, reader io.Reader, metadata map[string]*string, opts storage.StreamOptions) (string, string, error) {
+	s.gotOpts = opts
+	return s.Storage.Upload(ctx, key, bucket, reader, metadata)
+}
+
+func TestUploadService_UploadFileStream_UsesStreamUploaderWhenSupported(t *testing.T) {
+	fakeStorage := &streamOptsCapturingStorage{Storage: s3fake.NewStorage()}
+	s := NewUploadServiceWithStorage(fakeStorage)
+
+	opts := StreamUploadOptions{PartSize: 8 * 1024 * 1024, Concurrency: 5}
+	if _, err := s.UploadFileStream(context.Background(), bytes.NewReader([]byte("Hello, World!")), nil, aws.String("testfile.txt"), aws.String("testbucket"), opts); err != nil {
+		t.Fatalf("UploadFileStream() error = %v", err)
+	}
+
+	if fakeStorage.gotOpts.PartSize != opts.PartSize || fakeStorage.gotOpts.Concurrency != opts.Concurrency {
+		t.Errorf("UploadFileStream() passed StreamOptions %+v, want %+v", fakeStorage.gotOpts, storage.StreamOptions{PartSize: opts.PartSize, Concurrency: opts.Concurrency})
+	}
+}
+
+func TestUploadService_UploadFile_ChecksumMismatch(t *testing.T) {
+	s := NewUploadServiceWithStorage(s3fake.NewStorage())
+
+	content := []byte("Hello, World!")
+	_, err := s.UploadFile(bytes.NewReader(content), nil, aws.String("testfile.txt"), aws.String("testbucket"), UploadOptions{Checksum: "not-the-real-md5"})
+	if err == nil {
+		t.Error("UploadFile() error = nil, want error for checksum mismatch")
+	}
+}
+
@@ -0,0 +1,197 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "upload-service/proto"
+	"upload-service/storage"
+)
+
+// defaultPresignTTL is used when a presigned URL request doesn't specify
+// ExpiresSeconds. maxPresignTTL bounds how far a caller can push
+// ExpiresSeconds out, since S3 will happily mint a URL valid for years.
+const (
+	defaultPresignTTL = 15 * time.Minute
+	maxPresignTTL     = 7 * 24 * time.Hour
+)
+
+// Defaults for the multipart upload backing UploadMediaStream, applied
+// when the configured storage backend supports per-call tuning (see
+// storage.StreamUploader). 5MiB is the minimum part size S3 accepts.
+const (
+	defaultStreamPartSize    = 5 * 1024 * 1024
+	defaultStreamConcurrency = 3
+)
+
+// UploadHandler implements pb.UploadServiceServer by delegating to an
+// UploadService.
+type UploadHandler struct {
+	pb.UnimplementedUploadServiceServer
+	UploadService UploadService
+}
+
+func (h *UploadHandler) UploadMedia(ctx context.Context, request *pb.UploadMediaRequest) (*pb.UploadMediaResponse, error) {
+	metadata := make(map[string]*string, len(request.Metadata))
+	for k, v := range request.Metadata {
+		metadata[k] = aws.String(v)
+	}
+
+	location, err := h.UploadService.UploadFile(bytes.NewReader(request.File), metadata, aws.String(request.Key), aws.String(request.Bucket), UploadOptions{
+		ContentType: request.ContentType,
+		ForceGzip:   request.ForceGzip,
+		Overwrite:   request.Overwrite,
+		Checksum:    request.Checksum,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.UploadMediaResponse{Output: *location}, nil
+}
+
+// UploadMediaStream assembles the chunks sent over stream into an
+// io.Pipe and feeds it directly into UploadService.UploadFileStream, so
+// large payloads are never buffered in full. Key, bucket and metadata are
+// read from the first chunk; the client disconnecting or erroring mid
+// stream cancels the in-flight upload.
+func (h *UploadHandler) UploadMediaStream(stream pb.UploadService_UploadMediaStreamServer) error {
+	first, err := stream.Recv()
+	if err == io.EOF {
+		return status.Error(codes.InvalidArgument, "no chunks received")
+	}
+	if err != nil {
+		return err
+	}
+
+	if first.Key == "" {
+		return status.Error(codes.InvalidArgument, "key must not be empty")
+	}
+	if first.Bucket == "" {
+		return status.Error(codes.InvalidArgument, "bucket must not be empty")
+	}
+
+	metadata := make(map[string]*string, len(first.Metadata))
+	for k, v := range first.Metadata {
+		metadata[k] = aws.String(v)
+	}
+
+	ctx, cancel := context.WithCancel(stream.Context())
+	defer cancel()
+
+	pr, pw := io.Pipe()
+
+	uploadDone := make(chan error, 1)
+	var location *string
+	go func() {
+		loc, err := h.UploadService.UploadFileStream(ctx, pr, metadata, aws.String(first.Key), aws.String(first.Bucket), StreamUploadOptions{
+			PartSize:    defaultStreamPartSize,
+			Concurrency: defaultStreamConcurrency,
+		})
+		location = loc
+		uploadDone <- err
+	}()
+
+	if _, err := pw.Write(first.Chunk); err != nil {
+		pw.CloseWithError(err)
+		<-uploadDone
+		return err
+	}
+
+	for {
+		chunk, err := stream.Recv()
+		if err == io.EOF {
+			pw.Close()
+			break
+		}
+		if err != nil {
+			pw.CloseWithError(err)
+			<-uploadDone
+			return err
+		}
+		if _, err := pw.Write(chunk.Chunk); err != nil {
+			pw.CloseWithError(err)
+			<-uploadDone
+			return err
+		}
+	}
+
+	if err := <-uploadDone; err != nil {
+		return err
+	}
+
+	return stream.SendAndClose(&pb.UploadMediaResponse{Output: *location})
+}
+
+// GeneratePresignedUploadURL returns a URL the caller can PUT a file to
+// directly, so large uploads don't have to be proxied through this
+// service's UploadMedia/UploadMediaStream RPCs.
+func (h *UploadHandler) GeneratePresignedUploadURL(ctx context.Context, request *pb.PresignedUploadURLRequest) (*pb.PresignedUploadURLResponse, error) {
+	if request.Key == "" {
+		return nil, status.Error(codes.InvalidArgument, "key must not be empty")
+	}
+	if request.Bucket == "" {
+		return nil, status.Error(codes.InvalidArgument, "bucket must not be empty")
+	}
+
+	metadata := make(map[string]*string, len(request.Metadata))
+	for k, v := range request.Metadata {
+		metadata[k] = aws.String(v)
+	}
+
+	url, headers, err := h.UploadService.PresignUpload(ctx, request.Key, request.Bucket, request.ContentType, presignTTL(request.ExpiresSeconds), metadata)
+	if err != nil {
+		return nil, presignStatusError(err)
+	}
+
+	return &pb.PresignedUploadURLResponse{Url: url, Headers: headers}, nil
+}
+
+// GeneratePresignedDownloadURL returns a URL the caller can GET a file
+// from directly.
+func (h *UploadHandler) GeneratePresignedDownloadURL(ctx context.Context, request *pb.PresignedDownloadURLRequest) (*pb.PresignedDownloadURLResponse, error) {
+	if request.Key == "" {
+		return nil, status.Error(codes.InvalidArgument, "key must not be empty")
+	}
+	if request.Bucket == "" {
+		return nil, status.Error(codes.InvalidArgument, "bucket must not be empty")
+	}
+
+	url, err := h.UploadService.PresignDownload(ctx, request.Key, request.Bucket, presignTTL(request.ExpiresSeconds))
+	if err != nil {
+		return nil, presignStatusError(err)
+	}
+
+	return &pb.PresignedDownloadURLResponse{Url: url}, nil
+}
+
+// presignTTL converts expiresSeconds into a time.Duration, falling back to
+// defaultPresignTTL when unset and capping at maxPresignTTL so a caller
+// can't mint an effectively permanent URL.
+func presignTTL(expiresSeconds int64) time.Duration {
+	if expiresSeconds <= 0 {
+		return defaultPresignTTL
+	}
+	ttl := time.Duration(expiresSeconds) * time.Second
+	if ttl > maxPresignTTL {
+		return maxPresignTTL
+	}
+	return ttl
+}
+
+// presignStatusError maps storage-level presign errors to gRPC statuses
+// so callers can distinguish an unsupported backend from an unexpected
+// failure, instead of seeing a generic codes.Unknown.
+func presignStatusError(err error) error {
+	if errors.Is(err, storage.ErrPresignNotSupported) {
+		return status.Error(codes.FailedPrecondition, err.Error())
+	}
+	return err
+}
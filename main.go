@@ -0,0 +1,30 @@
+package main
+
+import (
+	"log"
+	"net"
+
+	"google.golang.org/grpc"
+
+	pb "upload-service/proto"
+)
+
+func main() {
+	uploadService, err := NewUploadService()
+	if err != nil {
+		log.Fatalf("failed to init storage backend: %v", err)
+	}
+
+	lis, err := net.Listen("tcp", ":50051")
+	if err != nil {
+		log.Fatalf("failed to listen: %v", err)
+	}
+
+	grpcServer := grpc.NewServer()
+	pb.RegisterUploadServiceServer(grpcServer, &UploadHandler{UploadService: *uploadService})
+
+	log.Println("upload-service listening on :50051")
+	if err := grpcServer.Serve(lis); err != nil {
+		log.Fatalf("failed to serve: %v", err)
+	}
+}
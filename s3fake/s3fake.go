@@ -0,0 +1,102 @@
+// Package s3fake provides an in-memory implementation of storage.Storage
+// so UploadService can be exercised in unit tests without a live
+// S3/MinIO/local filesystem backend.
+package s3fake
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"sync"
+
+	"upload-service/storage"
+)
+
+// Object is a single object stored in a fake bucket.
+type Object struct {
+	Body            []byte
+	Metadata        map[string]*string
+	ContentType     string
+	ContentEncoding string
+	ETag            string
+}
+
+// Storage is an in-memory implementation of storage.Storage.
+type Storage struct {
+	mu      sync.Mutex
+	Objects map[string]map[string]*Object // bucket -> key -> object
+}
+
+// NewStorage returns an empty Storage.
+func NewStorage() *Storage {
+	return &Storage{Objects: make(map[string]map[string]*Object)}
+}
+
+// Upload stores reader's content in memory.
+func (s *Storage) Upload(ctx context.Context, key, bucket string, reader io.Reader, metadata map[string]*string) (string, string, error) {
+	body, err := io.ReadAll(reader)
+	if err != nil {
+		return "", "", err
+	}
+
+	sum := md5.Sum(body)
+	obj := &Object{Body: body, Metadata: make(map[string]*string, len(metadata)), ETag: hex.EncodeToString(sum[:])}
+	for k, v := range metadata {
+		switch k {
+		case storage.MetadataContentType:
+			if v != nil {
+				obj.ContentType = *v
+			}
+		case storage.MetadataContentEncoding:
+			if v != nil {
+				obj.ContentEncoding = *v
+			}
+		default:
+			obj.Metadata[k] = v
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.Objects[bucket] == nil {
+		s.Objects[bucket] = make(map[string]*Object)
+	}
+	s.Objects[bucket][key] = obj
+
+	return fmt.Sprintf("fake://%s/%s", bucket, key), obj.ETag, nil
+}
+
+// Head reports whether an object exists at bucket/key and, if so, its
+// ETag and location.
+func (s *Storage) Head(ctx context.Context, key, bucket string) (string, string, bool, error) {
+	obj, ok := s.GetObject(bucket, key)
+	if !ok {
+		return "", "", false, nil
+	}
+	return obj.ETag, fmt.Sprintf("fake://%s/%s", bucket, key), true, nil
+}
+
+// Get returns the stored body for bucket/key, or ok=false if no such object
+// was uploaded.
+func (s *Storage) Get(bucket, key string) (body []byte, ok bool) {
+	obj, ok := s.GetObject(bucket, key)
+	if !ok {
+		return nil, false
+	}
+	return obj.Body, true
+}
+
+// GetObject returns the stored object for bucket/key, or ok=false if no
+// such object was uploaded.
+func (s *Storage) GetObject(bucket, key string) (obj *Object, ok bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	objs, ok := s.Objects[bucket]
+	if !ok {
+		return nil, false
+	}
+	obj, ok = objs[key]
+	return obj, ok
+}
@@ -0,0 +1,103 @@
+package storage
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// etagSuffix names the sidecar file LocalStorage stores an object's MD5
+// digest in, since the local filesystem has no native ETag equivalent.
+const etagSuffix = ".etag"
+
+// LocalStorage stores objects under a root directory on the local
+// filesystem. It exists so the service can run in air-gapped dev
+// environments with no S3/MinIO endpoint available.
+type LocalStorage struct {
+	root    string
+	baseURL string
+}
+
+// NewLocalStorage builds a LocalStorage rooted at root. If
+// LOCAL_STORAGE_BASE_URL is set, returned locations are HTTP URLs under
+// it; otherwise they are file:// URLs into root.
+func NewLocalStorage(root string) (*LocalStorage, error) {
+	if root == "" {
+		return nil, fmt.Errorf("storage: LOCAL_STORAGE_ROOT must be set for the filesystem driver")
+	}
+	if err := os.MkdirAll(root, 0o755); err != nil {
+		return nil, fmt.Errorf("storage: create root dir: %w", err)
+	}
+
+	return &LocalStorage{root: root, baseURL: os.Getenv("LOCAL_STORAGE_BASE_URL")}, nil
+}
+
+func (s *LocalStorage) Upload(ctx context.Context, key, bucket string, reader io.Reader, metadata map[string]*string) (string, string, error) {
+	path, err := s.resolve(bucket, key)
+	if err != nil {
+		return "", "", err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return "", "", fmt.Errorf("storage: create bucket dir: %w", err)
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return "", "", fmt.Errorf("storage: create object file: %w", err)
+	}
+	defer f.Close()
+
+	h := md5.New()
+	if _, err := io.Copy(f, io.TeeReader(reader, h)); err != nil {
+		return "", "", fmt.Errorf("storage: write object file: %w", err)
+	}
+	etag := hex.EncodeToString(h.Sum(nil))
+
+	if err := os.WriteFile(path+etagSuffix, []byte(etag), 0o644); err != nil {
+		return "", "", fmt.Errorf("storage: write etag sidecar: %w", err)
+	}
+
+	return s.location(bucket, key, path), etag, nil
+}
+
+func (s *LocalStorage) Head(ctx context.Context, key, bucket string) (string, string, bool, error) {
+	path, err := s.resolve(bucket, key)
+	if err != nil {
+		return "", "", false, err
+	}
+
+	etag, err := os.ReadFile(path + etagSuffix)
+	if errors.Is(err, os.ErrNotExist) {
+		return "", "", false, nil
+	}
+	if err != nil {
+		return "", "", false, fmt.Errorf("storage: read etag sidecar: %w", err)
+	}
+
+	return string(etag), s.location(bucket, key, path), true, nil
+}
+
+// resolve joins bucket/key onto root and verifies the result did not
+// escape it, so a client-supplied key/bucket containing ".." segments
+// can't be used to read or write files elsewhere on the host.
+func (s *LocalStorage) resolve(bucket, key string) (string, error) {
+	path := filepath.Join(s.root, bucket, key)
+	rel, err := filepath.Rel(s.root, path)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("storage: key/bucket resolves outside storage root")
+	}
+	return path, nil
+}
+
+func (s *LocalStorage) location(bucket, key, path string) string {
+	if s.baseURL != "" {
+		return fmt.Sprintf("%s/%s/%s", strings.TrimSuffix(s.baseURL, "/"), bucket, key)
+	}
+	return "file://" + path
+}
@@ -0,0 +1,126 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLocalStorage_Upload(t *testing.T) {
+	root := t.TempDir()
+
+	s, err := NewLocalStorage(root)
+	if err != nil {
+		t.Fatalf("NewLocalStorage() error = %v", err)
+	}
+
+	content := []byte("Hello, World!")
+	location, etag, err := s.Upload(context.Background(), "testfolder/testfile.txt", "testbucket", bytes.NewReader(content), nil)
+	if err != nil {
+		t.Fatalf("Upload() error = %v", err)
+	}
+	if etag == "" {
+		t.Error("Upload() etag is empty")
+	}
+
+	wantLocation := "file://" + filepath.Join(root, "testbucket", "testfolder/testfile.txt")
+	if location != wantLocation {
+		t.Errorf("Upload() location = %q, want %q", location, wantLocation)
+	}
+
+	got, err := os.ReadFile(filepath.Join(root, "testbucket", "testfolder/testfile.txt"))
+	if err != nil {
+		t.Fatalf("failed to read stored object: %v", err)
+	}
+	if !bytes.Equal(got, content) {
+		t.Errorf("stored object = %q, want %q", got, content)
+	}
+
+	headETag, headLocation, exists, err := s.Head(context.Background(), "testfolder/testfile.txt", "testbucket")
+	if err != nil {
+		t.Fatalf("Head() error = %v", err)
+	}
+	if !exists {
+		t.Fatal("Head() exists = false, want true after Upload")
+	}
+	if headETag != etag {
+		t.Errorf("Head() etag = %q, want %q", headETag, etag)
+	}
+	if headLocation != wantLocation {
+		t.Errorf("Head() location = %q, want %q", headLocation, wantLocation)
+	}
+}
+
+func TestLocalStorage_Head_NotFound(t *testing.T) {
+	s, err := NewLocalStorage(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewLocalStorage() error = %v", err)
+	}
+
+	_, _, exists, err := s.Head(context.Background(), "missing.txt", "testbucket")
+	if err != nil {
+		t.Fatalf("Head() error = %v", err)
+	}
+	if exists {
+		t.Error("Head() exists = true, want false for an object that was never uploaded")
+	}
+}
+
+func TestLocalStorage_Upload_WithBaseURL(t *testing.T) {
+	root := t.TempDir()
+	t.Setenv("LOCAL_STORAGE_BASE_URL", "http://localhost:8080/media")
+
+	s, err := NewLocalStorage(root)
+	if err != nil {
+		t.Fatalf("NewLocalStorage() error = %v", err)
+	}
+
+	location, _, err := s.Upload(context.Background(), "testfile.txt", "testbucket", bytes.NewReader([]byte("Hello, World!")), nil)
+	if err != nil {
+		t.Fatalf("Upload() error = %v", err)
+	}
+
+	want := "http://localhost:8080/media/testbucket/testfile.txt"
+	if location != want {
+		t.Errorf("Upload() location = %q, want %q", location, want)
+	}
+}
+
+func TestLocalStorage_Upload_RejectsPathTraversal(t *testing.T) {
+	root := t.TempDir()
+
+	s, err := NewLocalStorage(root)
+	if err != nil {
+		t.Fatalf("NewLocalStorage() error = %v", err)
+	}
+
+	if _, _, err := s.Upload(context.Background(), "../../etc/evil.txt", "testbucket", bytes.NewReader([]byte("pwned")), nil); err == nil {
+		t.Error("Upload() error = nil, want error for key escaping storage root")
+	}
+	if _, _, err := s.Upload(context.Background(), "evil.txt", "../../etc", bytes.NewReader([]byte("pwned")), nil); err == nil {
+		t.Error("Upload() error = nil, want error for bucket escaping storage root")
+	}
+
+	if _, err := os.Stat(filepath.Join(filepath.Dir(root), "etc", "evil.txt")); !os.IsNotExist(err) {
+		t.Error("Upload() wrote a file outside the storage root")
+	}
+}
+
+func TestLocalStorage_Head_RejectsPathTraversal(t *testing.T) {
+	s, err := NewLocalStorage(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewLocalStorage() error = %v", err)
+	}
+
+	if _, _, _, err := s.Head(context.Background(), "../../etc/passwd", "testbucket"); err == nil {
+		t.Error("Head() error = nil, want error for key escaping storage root")
+	}
+}
+
+func TestNewLocalStorage_EmptyRoot(t *testing.T) {
+	if _, err := NewLocalStorage(""); err == nil {
+		t.Error("NewLocalStorage() error = nil, want error for empty root")
+	}
+}
@@ -0,0 +1,105 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	minio "github.com/minio/minio-go/v6"
+	"github.com/minio/minio-go/v6/pkg/credentials"
+)
+
+// minioClient is the subset of *minio.Client that MinioStorage depends
+// on, so tests can substitute a fake.
+type minioClient interface {
+	PutObjectWithContext(ctx context.Context, bucketName, objectName string, reader io.Reader, objectSize int64, opts minio.PutObjectOptions) (int64, error)
+	StatObjectWithContext(ctx context.Context, bucketName, objectName string, opts minio.StatObjectOptions) (minio.ObjectInfo, error)
+}
+
+// MinioStorage stores objects in a MinIO (or other S3-compatible) bucket
+// via minio-go.
+type MinioStorage struct {
+	client   minioClient
+	endpoint string
+}
+
+// NewMinioStorage builds a MinioStorage from the MINIO_ENDPOINT,
+// MINIO_ACCESS_KEY, MINIO_SECRET_KEY and MINIO_USE_SSL environment
+// variables. MINIO_SIGNATURE_VERSION selects "v2" or "v4" (default "v4"),
+// since some older MinIO/S3-compatible deployments only support v2.
+func NewMinioStorage() (*MinioStorage, error) {
+	endpoint := os.Getenv("MINIO_ENDPOINT")
+	accessKey := os.Getenv("MINIO_ACCESS_KEY")
+	secretKey := os.Getenv("MINIO_SECRET_KEY")
+	useSSL := os.Getenv("MINIO_USE_SSL") == "true"
+
+	var creds *credentials.Credentials
+	if strings.EqualFold(os.Getenv("MINIO_SIGNATURE_VERSION"), "v2") {
+		creds = credentials.NewStaticV2(accessKey, secretKey, "")
+	} else {
+		creds = credentials.NewStaticV4(accessKey, secretKey, "")
+	}
+
+	client, err := minio.NewWithOptions(endpoint, &minio.Options{
+		Creds:  creds,
+		Secure: useSSL,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("storage: minio client: %w", err)
+	}
+
+	return &MinioStorage{client: client, endpoint: endpoint}, nil
+}
+
+// NewMinioStorageWithClient builds a MinioStorage around an
+// already-configured minioClient, e.g. a fake in tests.
+func NewMinioStorageWithClient(client minioClient, endpoint string) *MinioStorage {
+	return &MinioStorage{client: client, endpoint: endpoint}
+}
+
+func (s *MinioStorage) Upload(ctx context.Context, key, bucket string, reader io.Reader, metadata map[string]*string) (string, string, error) {
+	userMetadata, contentType, contentEncoding := splitReservedMetadata(metadata)
+
+	opts := minio.PutObjectOptions{UserMetadata: stringValues(userMetadata)}
+	if contentType != nil {
+		opts.ContentType = *contentType
+	}
+	if contentEncoding != nil {
+		opts.ContentEncoding = *contentEncoding
+	}
+
+	if _, err := s.client.PutObjectWithContext(ctx, bucket, key, reader, -1, opts); err != nil {
+		return "", "", fmt.Errorf("storage: minio upload: %w", err)
+	}
+
+	etag, _, _, err := s.Head(ctx, key, bucket)
+	if err != nil {
+		return "", "", fmt.Errorf("storage: minio stat after upload: %w", err)
+	}
+
+	return fmt.Sprintf("%s/%s/%s", s.endpoint, bucket, key), etag, nil
+}
+
+func (s *MinioStorage) Head(ctx context.Context, key, bucket string) (string, string, bool, error) {
+	info, err := s.client.StatObjectWithContext(ctx, bucket, key, minio.StatObjectOptions{})
+	if err != nil {
+		if minio.ToErrorResponse(err).Code == "NoSuchKey" {
+			return "", "", false, nil
+		}
+		return "", "", false, fmt.Errorf("storage: minio stat: %w", err)
+	}
+
+	return info.ETag, fmt.Sprintf("%s/%s/%s", s.endpoint, bucket, key), true, nil
+}
+
+func stringValues(m map[string]*string) map[string]string {
+	out := make(map[string]string, len(m))
+	for k, v := range m {
+		if v != nil {
+			out[k] = *v
+		}
+	}
+	return out
+}
@@ -0,0 +1,90 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	minio "github.com/minio/minio-go/v6"
+)
+
+// fakeMinioClient is a minimal minioClient that records the PutObject
+// call it received and returns canned stat results/errors.
+type fakeMinioClient struct {
+	gotOpts minio.PutObjectOptions
+	putErr  error
+
+	statInfo minio.ObjectInfo
+	statErr  error
+}
+
+func (f *fakeMinioClient) PutObjectWithContext(ctx context.Context, bucketName, objectName string, reader io.Reader, objectSize int64, opts minio.PutObjectOptions) (int64, error) {
+	f.gotOpts = opts
+	if f.putErr != nil {
+		return 0, f.putErr
+	}
+	return 0, nil
+}
+
+func (f *fakeMinioClient) StatObjectWithContext(ctx context.Context, bucketName, objectName string, opts minio.StatObjectOptions) (minio.ObjectInfo, error) {
+	return f.statInfo, f.statErr
+}
+
+func TestMinioStorage_Upload_SplitsContentTypeAndEncoding(t *testing.T) {
+	client := &fakeMinioClient{statInfo: minio.ObjectInfo{ETag: "abc123"}}
+	s := NewMinioStorageWithClient(client, "http://minio.example.com")
+
+	metadata := map[string]*string{
+		MetadataContentType:     aws.String("text/plain"),
+		MetadataContentEncoding: aws.String("gzip"),
+		"x-custom":              aws.String("value"),
+	}
+	location, etag, err := s.Upload(context.Background(), "testfile.txt", "testbucket", bytes.NewReader([]byte("hello")), metadata)
+	if err != nil {
+		t.Fatalf("Upload() error = %v", err)
+	}
+	if etag != "abc123" {
+		t.Errorf("Upload() etag = %q, want %q", etag, "abc123")
+	}
+	if want := "http://minio.example.com/testbucket/testfile.txt"; location != want {
+		t.Errorf("Upload() location = %q, want %q", location, want)
+	}
+
+	if client.gotOpts.ContentType != "text/plain" {
+		t.Errorf("PutObjectOptions.ContentType = %q, want %q", client.gotOpts.ContentType, "text/plain")
+	}
+	if client.gotOpts.ContentEncoding != "gzip" {
+		t.Errorf("PutObjectOptions.ContentEncoding = %q, want %q", client.gotOpts.ContentEncoding, "gzip")
+	}
+	if _, ok := client.gotOpts.UserMetadata[MetadataContentType]; ok {
+		t.Error("PutObjectOptions.UserMetadata still carries the reserved Content-Type key")
+	}
+	if got := client.gotOpts.UserMetadata["x-custom"]; got != "value" {
+		t.Errorf("PutObjectOptions.UserMetadata[x-custom] = %q, want %q", got, "value")
+	}
+}
+
+func TestMinioStorage_Head_NotFoundReturnsExistsFalse(t *testing.T) {
+	client := &fakeMinioClient{statErr: minio.ErrorResponse{Code: "NoSuchKey"}}
+	s := NewMinioStorageWithClient(client, "http://minio.example.com")
+
+	_, _, exists, err := s.Head(context.Background(), "missing.txt", "testbucket")
+	if err != nil {
+		t.Fatalf("Head() error = %v, want nil", err)
+	}
+	if exists {
+		t.Error("Head() exists = true, want false for NoSuchKey")
+	}
+}
+
+func TestMinioStorage_Head_OtherErrorPropagates(t *testing.T) {
+	client := &fakeMinioClient{statErr: errors.New("connection reset")}
+	s := NewMinioStorageWithClient(client, "http://minio.example.com")
+
+	if _, _, _, err := s.Head(context.Background(), "testfile.txt", "testbucket"); err == nil {
+		t.Error("Head() error = nil, want error for a non-NoSuchKey failure")
+	}
+}
@@ -0,0 +1,208 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3manager"
+)
+
+// s3Uploader is the subset of s3manager.Uploader that S3Storage depends
+// on, so tests can substitute a fake.
+type s3Uploader interface {
+	UploadWithContext(ctx aws.Context, input *s3manager.UploadInput, opts ...func(*s3manager.Uploader)) (*s3manager.UploadOutput, error)
+}
+
+// s3HeadObjectAPI is the subset of *s3.S3 that S3Storage depends on to
+// check whether an object already exists.
+type s3HeadObjectAPI interface {
+	HeadObjectWithContext(ctx aws.Context, input *s3.HeadObjectInput, opts ...request.Option) (*s3.HeadObjectOutput, error)
+}
+
+// s3PresignAPI is the subset of *s3.S3 that S3Storage depends on to build
+// presigned upload/download URLs.
+type s3PresignAPI interface {
+	PutObjectRequest(input *s3.PutObjectInput) (*request.Request, *s3.PutObjectOutput)
+	GetObjectRequest(input *s3.GetObjectInput) (*request.Request, *s3.GetObjectOutput)
+}
+
+// S3Storage stores objects in an S3-compatible bucket using
+// s3manager.Uploader, which handles multipart uploads transparently.
+type S3Storage struct {
+	uploader      s3Uploader
+	headClient    s3HeadObjectAPI
+	presignClient s3PresignAPI
+	endpoint      string
+	partSize      int64
+	concurrency   int
+}
+
+// S3StorageOption configures an S3Storage built by NewS3Storage.
+type S3StorageOption func(*S3Storage)
+
+// WithPartSize sets the size in bytes of each part sent to S3.
+func WithPartSize(size int64) S3StorageOption {
+	return func(s *S3Storage) { s.partSize = size }
+}
+
+// WithConcurrency sets the number of parts uploaded in parallel.
+func WithConcurrency(n int) S3StorageOption {
+	return func(s *S3Storage) { s.concurrency = n }
+}
+
+// NewS3Storage builds an S3Storage against endpoint, picking up
+// credentials from the S3_ACCESS_KEY/S3_SECRET_KEY environment variables.
+func NewS3Storage(endpoint string, opts ...S3StorageOption) *S3Storage {
+	cfg := &aws.Config{
+		Credentials:      credentials.NewStaticCredentials(os.Getenv("S3_ACCESS_KEY"), os.Getenv("S3_SECRET_KEY"), ""),
+		Endpoint:         aws.String(endpoint),
+		Region:           aws.String("eu-east-1"),
+		DisableSSL:       aws.Bool(true),
+		S3ForcePathStyle: aws.Bool(true),
+	}
+	sess := session.Must(session.NewSession(cfg))
+
+	s3Client := s3.New(sess)
+	s := &S3Storage{uploader: s3manager.NewUploader(sess), headClient: s3Client, presignClient: s3Client, endpoint: endpoint}
+	if partSizeMB, err := strconv.ParseInt(os.Getenv("S3_PART_SIZE_MB"), 10, 64); err == nil {
+		s.partSize = partSizeMB * 1024 * 1024
+	}
+	if concurrency, err := strconv.Atoi(os.Getenv("S3_CONCURRENCY")); err == nil {
+		s.concurrency = concurrency
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
+}
+
+// NewS3StorageWithClients builds an S3Storage around already-configured
+// uploader, head-object and presign clients, e.g. fakes in tests.
+func NewS3StorageWithClients(uploader s3Uploader, headClient s3HeadObjectAPI, presignClient s3PresignAPI, endpoint string) *S3Storage {
+	return &S3Storage{uploader: uploader, headClient: headClient, presignClient: presignClient, endpoint: endpoint}
+}
+
+func (s *S3Storage) Upload(ctx context.Context, key, bucket string, reader io.Reader, metadata map[string]*string) (string, string, error) {
+	return s.upload(ctx, key, bucket, reader, metadata, s.partSize, s.concurrency)
+}
+
+// UploadStream uploads like Upload, but lets the caller override the
+// part size/concurrency otherwise configured via S3_PART_SIZE_MB/
+// S3_CONCURRENCY for this call only, e.g. to tune a large streamed
+// upload differently from the service-wide defaults.
+func (s *S3Storage) UploadStream(ctx context.Context, key, bucket string, reader io.Reader, metadata map[string]*string, opts StreamOptions) (string, string, error) {
+	partSize := s.partSize
+	if opts.PartSize > 0 {
+		partSize = opts.PartSize
+	}
+	concurrency := s.concurrency
+	if opts.Concurrency > 0 {
+		concurrency = opts.Concurrency
+	}
+	return s.upload(ctx, key, bucket, reader, metadata, partSize, concurrency)
+}
+
+func (s *S3Storage) upload(ctx context.Context, key, bucket string, reader io.Reader, metadata map[string]*string, partSize int64, concurrency int) (string, string, error) {
+	userMetadata, contentType, contentEncoding := splitReservedMetadata(metadata)
+
+	input := &s3manager.UploadInput{
+		Bucket:          aws.String(bucket),
+		Key:             aws.String(key),
+		Body:            reader,
+		Metadata:        userMetadata,
+		ContentType:     contentType,
+		ContentEncoding: contentEncoding,
+	}
+
+	out, err := s.uploader.UploadWithContext(ctx, input, func(u *s3manager.Uploader) {
+		if partSize > 0 {
+			u.PartSize = partSize
+		}
+		if concurrency > 0 {
+			u.Concurrency = concurrency
+		}
+	})
+	if err != nil {
+		return "", "", fmt.Errorf("storage: s3 upload: %w", err)
+	}
+
+	return fmt.Sprintf("%s/%s/%s", s.endpoint, bucket, key), unquoteETag(aws.StringValue(out.ETag)), nil
+}
+
+func (s *S3Storage) Head(ctx context.Context, key, bucket string) (string, string, bool, error) {
+	out, err := s.headClient.HeadObjectWithContext(ctx, &s3.HeadObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		if awsErr, ok := err.(awserr.RequestFailure); ok && awsErr.StatusCode() == 404 {
+			return "", "", false, nil
+		}
+		return "", "", false, fmt.Errorf("storage: s3 head: %w", err)
+	}
+
+	return unquoteETag(aws.StringValue(out.ETag)), fmt.Sprintf("%s/%s/%s", s.endpoint, bucket, key), true, nil
+}
+
+// unquoteETag strips the double quotes S3 wraps ETags in.
+func unquoteETag(etag string) string {
+	return strings.Trim(etag, `"`)
+}
+
+// PresignUpload returns a URL the caller can PUT to directly, along with
+// the headers that PUT must carry for the signature to validate.
+func (s *S3Storage) PresignUpload(ctx context.Context, key, bucket, contentType string, ttl time.Duration, metadata map[string]*string) (string, map[string]string, error) {
+	userMetadata, reservedContentType, contentEncoding := splitReservedMetadata(metadata)
+	if contentType != "" {
+		reservedContentType = aws.String(contentType)
+	}
+
+	req, _ := s.presignClient.PutObjectRequest(&s3.PutObjectInput{
+		Bucket:          aws.String(bucket),
+		Key:             aws.String(key),
+		ContentType:     reservedContentType,
+		ContentEncoding: contentEncoding,
+		Metadata:        userMetadata,
+	})
+	req.SetContext(ctx)
+
+	url, signedHeaders, err := req.PresignRequest(ttl)
+	if err != nil {
+		return "", nil, fmt.Errorf("storage: s3 presign upload: %w", err)
+	}
+
+	headers := make(map[string]string, len(signedHeaders))
+	for k, v := range signedHeaders {
+		if len(v) > 0 {
+			headers[k] = v[0]
+		}
+	}
+	return url, headers, nil
+}
+
+// PresignDownload returns a URL the caller can GET directly.
+func (s *S3Storage) PresignDownload(ctx context.Context, key, bucket string, ttl time.Duration) (string, error) {
+	req, _ := s.presignClient.GetObjectRequest(&s3.GetObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(key),
+	})
+	req.SetContext(ctx)
+
+	url, err := req.Presign(ttl)
+	if err != nil {
+		return "", fmt.Errorf("storage: s3 presign download: %w", err)
+	}
+	return url, nil
+}
@@ -0,0 +1,162 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3manager"
+)
+
+// fakeS3Uploader is a minimal s3Uploader that records the input it was
+// called with and returns a canned ETag/error.
+type fakeS3Uploader struct {
+	gotInput *s3manager.UploadInput
+	etag     string
+	err      error
+}
+
+func (f *fakeS3Uploader) UploadWithContext(ctx aws.Context, input *s3manager.UploadInput, opts ...func(*s3manager.Uploader)) (*s3manager.UploadOutput, error) {
+	f.gotInput = input
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &s3manager.UploadOutput{ETag: aws.String(f.etag)}, nil
+}
+
+// fakeS3HeadClient is a minimal s3HeadObjectAPI returning a canned
+// output/error.
+type fakeS3HeadClient struct {
+	out *s3.HeadObjectOutput
+	err error
+}
+
+func (f *fakeS3HeadClient) HeadObjectWithContext(ctx aws.Context, input *s3.HeadObjectInput, opts ...request.Option) (*s3.HeadObjectOutput, error) {
+	return f.out, f.err
+}
+
+func TestS3Storage_Upload_UnquotesETag(t *testing.T) {
+	uploader := &fakeS3Uploader{etag: `"abc123"`}
+	s := NewS3StorageWithClients(uploader, nil, nil, "http://s3.example.com")
+
+	location, etag, err := s.Upload(context.Background(), "testfile.txt", "testbucket", bytes.NewReader([]byte("hello")), nil)
+	if err != nil {
+		t.Fatalf("Upload() error = %v", err)
+	}
+	if etag != "abc123" {
+		t.Errorf("Upload() etag = %q, want unquoted %q", etag, "abc123")
+	}
+	if want := "http://s3.example.com/testbucket/testfile.txt"; location != want {
+		t.Errorf("Upload() location = %q, want %q", location, want)
+	}
+}
+
+func TestS3Storage_Upload_SplitsContentTypeAndEncoding(t *testing.T) {
+	uploader := &fakeS3Uploader{etag: "abc123"}
+	s := NewS3StorageWithClients(uploader, nil, nil, "http://s3.example.com")
+
+	metadata := map[string]*string{
+		MetadataContentType:     aws.String("text/plain"),
+		MetadataContentEncoding: aws.String("gzip"),
+		"x-custom":              aws.String("value"),
+	}
+	if _, _, err := s.Upload(context.Background(), "testfile.txt", "testbucket", bytes.NewReader([]byte("hello")), metadata); err != nil {
+		t.Fatalf("Upload() error = %v", err)
+	}
+
+	if got := aws.StringValue(uploader.gotInput.ContentType); got != "text/plain" {
+		t.Errorf("UploadInput.ContentType = %q, want %q", got, "text/plain")
+	}
+	if got := aws.StringValue(uploader.gotInput.ContentEncoding); got != "gzip" {
+		t.Errorf("UploadInput.ContentEncoding = %q, want %q", got, "gzip")
+	}
+	if _, ok := uploader.gotInput.Metadata[MetadataContentType]; ok {
+		t.Error("UploadInput.Metadata still carries the reserved Content-Type key")
+	}
+	if got := aws.StringValue(uploader.gotInput.Metadata["x-custom"]); got != "value" {
+		t.Errorf("UploadInput.Metadata[x-custom] = %q, want %q", got, "value")
+	}
+}
+
+func TestS3Storage_Head_NotFoundReturnsExistsFalse(t *testing.T) {
+	headClient := &fakeS3HeadClient{err: awserr.NewRequestFailure(awserr.New("NotFound", "not found", nil), 404, "req-id")}
+	s := NewS3StorageWithClients(nil, headClient, nil, "http://s3.example.com")
+
+	_, _, exists, err := s.Head(context.Background(), "missing.txt", "testbucket")
+	if err != nil {
+		t.Fatalf("Head() error = %v, want nil", err)
+	}
+	if exists {
+		t.Error("Head() exists = true, want false for a 404")
+	}
+}
+
+func TestS3Storage_Head_OtherErrorPropagates(t *testing.T) {
+	headClient := &fakeS3HeadClient{err: errors.New("connection reset")}
+	s := NewS3StorageWithClients(nil, headClient, nil, "http://s3.example.com")
+
+	if _, _, _, err := s.Head(context.Background(), "testfile.txt", "testbucket"); err == nil {
+		t.Error("Head() error = nil, want error for a non-404 failure")
+	}
+}
+
+// realPresignClient builds a real *s3.S3 client against fake credentials
+// and a fake endpoint. PutObjectRequest/GetObjectRequest/PresignRequest
+// never hit the network - they only build and locally sign a request -
+// so this exercises S3Storage's actual presign plumbing without a fake.
+func realPresignClient(t *testing.T) *s3.S3 {
+	t.Helper()
+	sess, err := session.NewSession(&aws.Config{
+		Credentials:      credentials.NewStaticCredentials("id", "secret", ""),
+		Region:           aws.String("us-east-1"),
+		Endpoint:         aws.String("http://s3.example.com"),
+		DisableSSL:       aws.Bool(true),
+		S3ForcePathStyle: aws.Bool(true),
+	})
+	if err != nil {
+		t.Fatalf("session.NewSession() error = %v", err)
+	}
+	return s3.New(sess)
+}
+
+func TestS3Storage_PresignUpload(t *testing.T) {
+	s := NewS3StorageWithClients(nil, nil, realPresignClient(t), "http://s3.example.com")
+
+	url, headers, err := s.PresignUpload(context.Background(), "testfile.txt", "testbucket", "text/plain", 15*time.Minute, nil)
+	if err != nil {
+		t.Fatalf("PresignUpload() error = %v", err)
+	}
+	if !strings.Contains(url, "testbucket/testfile.txt") {
+		t.Errorf("PresignUpload() url = %q, want it to reference bucket/key", url)
+	}
+	if !strings.Contains(url, "X-Amz-Signature") {
+		t.Errorf("PresignUpload() url = %q, want a signed URL", url)
+	}
+	if headers["content-type"] != "text/plain" {
+		t.Errorf("PresignUpload() headers[content-type] = %q, want %q", headers["content-type"], "text/plain")
+	}
+}
+
+func TestS3Storage_PresignDownload(t *testing.T) {
+	s := NewS3StorageWithClients(nil, nil, realPresignClient(t), "http://s3.example.com")
+
+	url, err := s.PresignDownload(context.Background(), "testfile.txt", "testbucket", 15*time.Minute)
+	if err != nil {
+		t.Fatalf("PresignDownload() error = %v", err)
+	}
+	if !strings.Contains(url, "testbucket/testfile.txt") {
+		t.Errorf("PresignDownload() url = %q, want it to reference bucket/key", url)
+	}
+	if !strings.Contains(url, "X-Amz-Signature") {
+		t.Errorf("PresignDownload() url = %q, want a signed URL", url)
+	}
+}
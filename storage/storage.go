@@ -0,0 +1,101 @@
+// Package storage abstracts the object store UploadService writes to, so
+// the service can run against S3, MinIO or a local filesystem without
+// changes to its upload logic. The backend is selected via the
+// STORAGE_DRIVER environment variable: "s3" (default), "minio" or
+// "filesystem".
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+)
+
+// Reserved metadata keys UploadService uses to pass Content-Type/
+// Content-Encoding through to backends that support native headers for
+// them, instead of storing them as opaque user metadata.
+const (
+	MetadataContentType     = "Content-Type"
+	MetadataContentEncoding = "Content-Encoding"
+)
+
+// Storage uploads a single object and returns the location it was stored
+// at along with its ETag, and can report whether an object already exists
+// at a given key so callers can skip re-uploading unchanged payloads.
+//
+// etag is best-effort: it is an MD5 digest for simple objects, but S3 and
+// MinIO do not guarantee that for multipart uploads, so it should be
+// treated as a bandwidth-saving heuristic rather than a cryptographic
+// guarantee.
+type Storage interface {
+	Upload(ctx context.Context, key, bucket string, reader io.Reader, metadata map[string]*string) (location, etag string, err error)
+	Head(ctx context.Context, key, bucket string) (etag, location string, exists bool, err error)
+}
+
+// StreamOptions tunes the multipart behavior of a StreamUploader's
+// UploadStream call. Zero values keep the backend's configured defaults.
+type StreamOptions struct {
+	// PartSize is the size in bytes of each part sent to the backend.
+	PartSize int64
+	// Concurrency is the number of parts uploaded in parallel.
+	Concurrency int
+}
+
+// StreamUploader is implemented by Storage backends that support tuning
+// multipart upload behavior per call, e.g. S3Storage. Backends without
+// native multipart support (e.g. LocalStorage) do not implement it;
+// callers should type-assert Storage to StreamUploader and fall back to
+// plain Upload if it's absent.
+type StreamUploader interface {
+	UploadStream(ctx context.Context, key, bucket string, reader io.Reader, metadata map[string]*string, opts StreamOptions) (location, etag string, err error)
+}
+
+// Presigner is implemented by Storage backends that can hand out
+// time-limited URLs for direct client PUT/GET, bypassing this service for
+// the transfer itself. Backends without native presigning support (e.g.
+// LocalStorage) do not implement it; callers should type-assert Storage
+// to Presigner and report ErrPresignNotSupported if it's absent.
+type Presigner interface {
+	PresignUpload(ctx context.Context, key, bucket, contentType string, ttl time.Duration, metadata map[string]*string) (url string, headers map[string]string, err error)
+	PresignDownload(ctx context.Context, key, bucket string, ttl time.Duration) (url string, err error)
+}
+
+// ErrPresignNotSupported is returned when the configured Storage backend
+// does not implement Presigner, so callers can distinguish "unsupported
+// backend" from an unexpected failure.
+var ErrPresignNotSupported = errors.New("storage: backend does not support presigned URLs")
+
+// NewFromEnv builds the Storage backend selected by STORAGE_DRIVER.
+func NewFromEnv() (Storage, error) {
+	switch driver := os.Getenv("STORAGE_DRIVER"); driver {
+	case "", "s3":
+		return NewS3Storage(os.Getenv("S3_ENDPOINT")), nil
+	case "minio":
+		return NewMinioStorage()
+	case "filesystem", "local":
+		return NewLocalStorage(os.Getenv("LOCAL_STORAGE_ROOT"))
+	default:
+		return nil, fmt.Errorf("storage: unknown STORAGE_DRIVER %q", driver)
+	}
+}
+
+// splitReservedMetadata pulls MetadataContentType/MetadataContentEncoding
+// out of metadata so backends that support native Content-Type/
+// Content-Encoding headers can set them directly.
+func splitReservedMetadata(metadata map[string]*string) (userMetadata map[string]*string, contentType, contentEncoding *string) {
+	userMetadata = make(map[string]*string, len(metadata))
+	for k, v := range metadata {
+		switch k {
+		case MetadataContentType:
+			contentType = v
+		case MetadataContentEncoding:
+			contentEncoding = v
+		default:
+			userMetadata[k] = v
+		}
+	}
+	return userMetadata, contentType, contentEncoding
+}
@@ -0,0 +1,24 @@
+package storage
+
+import "testing"
+
+func TestNewFromEnv_UnknownDriver(t *testing.T) {
+	t.Setenv("STORAGE_DRIVER", "bogus")
+
+	if _, err := NewFromEnv(); err == nil {
+		t.Error("NewFromEnv() error = nil, want error for unknown STORAGE_DRIVER")
+	}
+}
+
+func TestNewFromEnv_Filesystem(t *testing.T) {
+	t.Setenv("STORAGE_DRIVER", "filesystem")
+	t.Setenv("LOCAL_STORAGE_ROOT", t.TempDir())
+
+	backend, err := NewFromEnv()
+	if err != nil {
+		t.Fatalf("NewFromEnv() error = %v", err)
+	}
+	if _, ok := backend.(*LocalStorage); !ok {
+		t.Errorf("NewFromEnv() backend type = %T, want *LocalStorage", backend)
+	}
+}
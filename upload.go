@@ -0,0 +1,208 @@
+package main
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/gabriel-vasile/mimetype"
+
+	"upload-service/storage"
+)
+
+// UploadService uploads files through a pluggable storage.Storage backend.
+type UploadService struct {
+	storage storage.Storage
+}
+
+// NewUploadService builds an UploadService using the storage backend
+// selected by the STORAGE_DRIVER environment variable (s3, minio or
+// filesystem), defaulting to s3.
+func NewUploadService() (*UploadService, error) {
+	backend, err := storage.NewFromEnv()
+	if err != nil {
+		return nil, err
+	}
+	return &UploadService{storage: backend}, nil
+}
+
+// NewUploadServiceWithStorage builds an UploadService around an
+// already-configured storage.Storage, e.g. s3fake.NewStorage() in tests.
+func NewUploadServiceWithStorage(backend storage.Storage) *UploadService {
+	return &UploadService{storage: backend}
+}
+
+// UploadOptions carries the optional, non-positional parameters of
+// UploadFile.
+type UploadOptions struct {
+	// ContentType is used verbatim if set. Otherwise it is detected from
+	// the file's content.
+	ContentType string
+	// ForceGzip gzip-compresses file before upload and sets
+	// ContentEncoding to "gzip".
+	ForceGzip bool
+	// Overwrite skips the idempotency check below and always replaces an
+	// existing object at key.
+	Overwrite bool
+	// Checksum, if set, is an MD5 hex digest the caller expects the
+	// uploaded object to end up with; a mismatch against the storage
+	// backend's returned ETag is reported as an error.
+	Checksum string
+}
+
+// UploadFile uploads file to bucket/key and returns the resulting
+// location. Unless opts.Overwrite is set, it first checks whether an
+// object already exists at key with an ETag matching the MD5 digest of
+// the body that would actually be stored - i.e. the gzip-compressed
+// bytes when opts.ForceGzip is set, since that's what ends up at key and
+// what Head's ETag reflects - and if so skips the upload and returns the
+// existing location. This avoids wasted bandwidth on retried uploads of
+// identical media.
+func (s *UploadService) UploadFile(file io.Reader, metadata map[string]*string, key *string, bucket *string, opts UploadOptions) (*string, error) {
+	if key == nil || *key == "" {
+		return nil, errors.New("key must not be empty")
+	}
+	if bucket == nil || *bucket == "" {
+		return nil, errors.New("bucket must not be empty")
+	}
+
+	raw, err := io.ReadAll(file)
+	if err != nil {
+		return nil, fmt.Errorf("read upload payload: %w", err)
+	}
+
+	ctx := context.Background()
+
+	contentType := opts.ContentType
+	if contentType == "" {
+		contentType = mimetype.Detect(raw).String()
+	}
+
+	stored := raw
+	contentEncoding := ""
+	if opts.ForceGzip {
+		var buf bytes.Buffer
+		gw := gzip.NewWriter(&buf)
+		if _, err := gw.Write(raw); err != nil {
+			return nil, err
+		}
+		if err := gw.Close(); err != nil {
+			return nil, err
+		}
+		stored = buf.Bytes()
+		contentEncoding = "gzip"
+	}
+
+	if !opts.Overwrite {
+		sum := md5.Sum(stored)
+		incomingETag := hex.EncodeToString(sum[:])
+
+		existingETag, existingLocation, exists, err := s.storage.Head(ctx, *key, *bucket)
+		if err != nil {
+			return nil, err
+		}
+		if exists && existingETag == incomingETag {
+			return &existingLocation, nil
+		}
+	}
+
+	location, etag, err := s.storage.Upload(ctx, *key, *bucket, bytes.NewReader(stored), withReservedMetadata(metadata, contentType, contentEncoding))
+	if err != nil {
+		return nil, err
+	}
+
+	if opts.Checksum != "" && !strings.EqualFold(etag, opts.Checksum) {
+		return nil, fmt.Errorf("upload: stored ETag %q does not match client-supplied checksum %q, payload may be corrupted", etag, opts.Checksum)
+	}
+
+	return &location, nil
+}
+
+// StreamUploadOptions tunes the multipart upload UploadFileStream
+// performs when the underlying storage backend supports it (see
+// storage.StreamUploader); it is ignored by backends that don't.
+type StreamUploadOptions struct {
+	// PartSize is the size in bytes of each part sent to the backend.
+	// Zero keeps the backend's configured default.
+	PartSize int64
+	// Concurrency is the number of parts uploaded in parallel. Zero
+	// keeps the backend's configured default.
+	Concurrency int
+}
+
+// UploadFileStream uploads the content of r to bucket/key, without
+// buffering the whole payload in memory first. r is typically the read
+// side of an io.Pipe fed chunk-by-chunk from a gRPC stream. ctx
+// cancellation aborts the in-flight upload.
+func (s *UploadService) UploadFileStream(ctx context.Context, r io.Reader, metadata map[string]*string, key *string, bucket *string, opts StreamUploadOptions) (*string, error) {
+	if key == nil || *key == "" {
+		return nil, errors.New("key must not be empty")
+	}
+	if bucket == nil || *bucket == "" {
+		return nil, errors.New("bucket must not be empty")
+	}
+
+	if streamer, ok := s.storage.(storage.StreamUploader); ok {
+		location, _, err := streamer.UploadStream(ctx, *key, *bucket, r, metadata, storage.StreamOptions{
+			PartSize:    opts.PartSize,
+			Concurrency: opts.Concurrency,
+		})
+		if err != nil {
+			return nil, err
+		}
+		return &location, nil
+	}
+
+	location, _, err := s.storage.Upload(ctx, *key, *bucket, r, metadata)
+	if err != nil {
+		return nil, err
+	}
+	return &location, nil
+}
+
+// PresignUpload returns a URL the caller can PUT a file to directly,
+// bypassing this service for the transfer itself. It errors if the
+// configured storage backend does not support presigned URLs.
+func (s *UploadService) PresignUpload(ctx context.Context, key, bucket, contentType string, ttl time.Duration, metadata map[string]*string) (string, map[string]string, error) {
+	presigner, ok := s.storage.(storage.Presigner)
+	if !ok {
+		return "", nil, storage.ErrPresignNotSupported
+	}
+	return presigner.PresignUpload(ctx, key, bucket, contentType, ttl, metadata)
+}
+
+// PresignDownload returns a URL the caller can GET a file from directly.
+// It errors if the configured storage backend does not support presigned
+// URLs.
+func (s *UploadService) PresignDownload(ctx context.Context, key, bucket string, ttl time.Duration) (string, error) {
+	presigner, ok := s.storage.(storage.Presigner)
+	if !ok {
+		return "", storage.ErrPresignNotSupported
+	}
+	return presigner.PresignDownload(ctx, key, bucket, ttl)
+}
+
+// withReservedMetadata returns a copy of metadata carrying the reserved
+// Content-Type/Content-Encoding keys that storage.Storage backends
+// recognize.
+func withReservedMetadata(metadata map[string]*string, contentType, contentEncoding string) map[string]*string {
+	meta := make(map[string]*string, len(metadata)+2)
+	for k, v := range metadata {
+		meta[k] = v
+	}
+	if contentType != "" {
+		meta[storage.MetadataContentType] = aws.String(contentType)
+	}
+	if contentEncoding != "" {
+		meta[storage.MetadataContentEncoding] = aws.String(contentEncoding)
+	}
+	return meta
+}